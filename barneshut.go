@@ -0,0 +1,159 @@
+package main
+
+import "math"
+
+// barnesHutTheta is the default accuracy parameter: a node is treated
+// as a single pseudo-charge once its width over its distance to the
+// test point falls below this ratio.
+const barnesHutTheta = 0.5
+
+// barnesHutThreshold is the charge count above which fieldEvaluator
+// switches from direct O(Ntest*Ncharges) summation to the Barnes-Hut
+// approximation.
+const barnesHutThreshold = 50
+
+// minQuadrantSize stops subdivision once a quadrant gets this small,
+// so coincident or near-coincident charges don't recurse forever.
+const minQuadrantSize = 1e-9
+
+// bhNode is one node of a Barnes-Hut quadtree: either a leaf holding a
+// single charge, or an internal node holding the total charge and
+// charge-weighted centroid of everything beneath it.
+type bhNode struct {
+	xmin, ymin, xmax, ymax float64
+
+	totalCharge float64
+	centroidX   float64
+	centroidY   float64
+
+	leaf     *Point
+	children [4]*bhNode
+}
+
+// BarnesHutTree approximates the field of many charges by recursively
+// subdividing the bounding box into quadrants.
+type BarnesHutTree struct {
+	root  *bhNode
+	theta float64
+}
+
+// NewBarnesHutTree builds a tree over points within the current
+// [MinValue, MaxValue] bounding box, using the default theta.
+func NewBarnesHutTree(points []Point) *BarnesHutTree {
+	return NewBarnesHutTreeTheta(points, barnesHutTheta)
+}
+
+// NewBarnesHutTreeTheta builds a tree with an explicit accuracy
+// parameter; smaller theta is more accurate but slower.
+func NewBarnesHutTreeTheta(points []Point, theta float64) *BarnesHutTree {
+	return &BarnesHutTree{
+		root:  buildBHNode(points, MinValue, MinValue, MaxValue, MaxValue),
+		theta: theta,
+	}
+}
+
+func buildBHNode(points []Point, xmin, ymin, xmax, ymax float64) *bhNode {
+	if len(points) == 0 {
+		return nil
+	}
+
+	node := &bhNode{xmin: xmin, ymin: ymin, xmax: xmax, ymax: ymax}
+	for _, pt := range points {
+		node.totalCharge += pt.Charge
+		node.centroidX += pt.Charge * pt.X
+		node.centroidY += pt.Charge * pt.Y
+	}
+	if node.totalCharge != 0 {
+		node.centroidX /= node.totalCharge
+		node.centroidY /= node.totalCharge
+	} else {
+		for _, pt := range points {
+			node.centroidX += pt.X
+			node.centroidY += pt.Y
+		}
+		node.centroidX /= float64(len(points))
+		node.centroidY /= float64(len(points))
+	}
+
+	if len(points) == 1 {
+		node.leaf = &points[0]
+		return node
+	}
+
+	// A quadrant this small holds coincident or near-coincident
+	// charges; stop subdividing (it would recurse forever) but don't
+	// take the leaf shortcut either, since that would keep only
+	// points[0] and silently drop the rest. Leaving this as a
+	// childless non-leaf node means bhField falls back to its
+	// aggregate totalCharge/centroid, which already accounts for
+	// every point here.
+	if xmax-xmin < minQuadrantSize {
+		return node
+	}
+
+	midX := (xmin + xmax) / 2
+	midY := (ymin + ymax) / 2
+
+	var quadrants [4][]Point
+	for _, pt := range points {
+		q := bhQuadrant(pt, midX, midY)
+		quadrants[q] = append(quadrants[q], pt)
+	}
+
+	bounds := [4][4]float64{
+		{xmin, ymin, midX, midY}, // bottom-left
+		{midX, ymin, xmax, midY}, // bottom-right
+		{xmin, midY, midX, ymax}, // top-left
+		{midX, midY, xmax, ymax}, // top-right
+	}
+	for i, qpts := range quadrants {
+		node.children[i] = buildBHNode(qpts, bounds[i][0], bounds[i][1], bounds[i][2], bounds[i][3])
+	}
+
+	return node
+}
+
+func bhQuadrant(pt Point, midX, midY float64) int {
+	switch {
+	case pt.X < midX && pt.Y < midY:
+		return 0
+	case pt.X >= midX && pt.Y < midY:
+		return 1
+	case pt.X < midX && pt.Y >= midY:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// CalculateElectricFieldBH evaluates the field at (x, y) by walking
+// tree: a node is summed directly if it's a leaf, collapsed into a
+// single pseudo-charge at its centroid if s/d < theta (s the node
+// width, d the distance to the centroid), and otherwise recursed into.
+func CalculateElectricFieldBH(tree *BarnesHutTree, x, y float64) (float64, float64) {
+	return bhField(tree.root, x, y, tree.theta)
+}
+
+func bhField(n *bhNode, x, y, theta float64) (float64, float64) {
+	if n == nil {
+		return 0, 0
+	}
+
+	if n.leaf != nil {
+		return CalculateElectricField(n.leaf.Charge, n.leaf.X, n.leaf.Y, x, y)
+	}
+
+	d := math.Hypot(x-n.centroidX, y-n.centroidY)
+	s := n.xmax - n.xmin
+	if d > 0 && s/d < theta {
+		return CalculateElectricField(n.totalCharge, n.centroidX, n.centroidY, x, y)
+	}
+
+	var ex, ey float64
+	for _, child := range n.children {
+		fx, fy := bhField(child, x, y, theta)
+		ex += fx
+		ey += fy
+	}
+	return ex, ey
+}
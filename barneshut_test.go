@@ -0,0 +1,53 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func withBounds(minValue, maxValue float64, fn func()) {
+	origMin, origMax := MinValue, MaxValue
+	MinValue, MaxValue = minValue, maxValue
+	defer func() { MinValue, MaxValue = origMin, origMax }()
+	fn()
+}
+
+func TestBarnesHutMatchesDirectSumAtTheta0(t *testing.T) {
+	withBounds(0, 20, func() {
+		points := []Point{
+			{X: 3, Y: 4, Charge: 2},
+			{X: 10, Y: 15, Charge: -1},
+			{X: 7, Y: 2, Charge: 0.5},
+		}
+		// theta=0 never collapses a node into its pseudo-charge, so the
+		// tree walk degenerates to the same direct summation.
+		tree := NewBarnesHutTreeTheta(points, 0)
+
+		for _, tp := range []TestPoint{{X: 1, Y: 1}, {X: 19, Y: 19}, {X: 10, Y: 10}} {
+			wantEx, wantEy := sumField(points, tp.X, tp.Y)
+			gotEx, gotEy := CalculateElectricFieldBH(tree, tp.X, tp.Y)
+			if math.Abs(gotEx-wantEx) > 1e-6 || math.Abs(gotEy-wantEy) > 1e-6 {
+				t.Errorf("CalculateElectricFieldBH(%v) = (%v, %v), want (%v, %v)", tp, gotEx, gotEy, wantEx, wantEy)
+			}
+		}
+	})
+}
+
+func TestBarnesHutCoincidentCharges(t *testing.T) {
+	withBounds(0, 20, func() {
+		// Two charges at the same position land in the same quadrant at
+		// every subdivision, eventually hitting minQuadrantSize; the
+		// tree must keep both rather than silently keeping only one.
+		points := []Point{
+			{X: 5, Y: 5, Charge: 1},
+			{X: 5, Y: 5, Charge: 1},
+		}
+		tree := NewBarnesHutTree(points)
+
+		wantEx, wantEy := sumField(points, 8, 8)
+		gotEx, gotEy := CalculateElectricFieldBH(tree, 8, 8)
+		if math.Abs(gotEx-wantEx) > 1e-6 || math.Abs(gotEy-wantEy) > 1e-6 {
+			t.Errorf("CalculateElectricFieldBH() = (%v, %v), want (%v, %v) (both coincident charges counted)", gotEx, gotEy, wantEx, wantEy)
+		}
+	})
+}
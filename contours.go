@@ -0,0 +1,173 @@
+package main
+
+import (
+	"log"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+const coulombConstant = 8.987551787e9
+
+// ElectricPotential returns the scalar potential V = sum(k*q/r) at
+// (x, y) due to points, matching the k used by CalculateElectricField.
+func ElectricPotential(points []Point, x, y float64) float64 {
+	var v float64
+	for _, pt := range points {
+		dx := x - pt.X
+		dy := y - pt.Y
+		r := math.Hypot(dx, dy)
+		if r == 0 {
+			continue
+		}
+		v += coulombConstant * pt.Charge / r
+	}
+	return v
+}
+
+// AutoContourLevels picks n logarithmically spaced magnitudes covering
+// the range of |V| found on the grid and returns each one with both
+// signs, +mag and -mag, so 2n levels in all.
+func AutoContourLevels(points []Point, interval float64, n int) []float64 {
+	testPoints := GenerateTestPoints(interval, points)
+
+	minAbs, maxAbs := math.Inf(1), 0.0
+	for _, tp := range testPoints {
+		v := math.Abs(ElectricPotential(points, tp.X, tp.Y))
+		if v == 0 {
+			continue
+		}
+		if v < minAbs {
+			minAbs = v
+		}
+		if v > maxAbs {
+			maxAbs = v
+		}
+	}
+	if math.IsInf(minAbs, 1) || maxAbs <= minAbs {
+		return nil
+	}
+
+	logMin, logMax := math.Log(minAbs), math.Log(maxAbs)
+	levels := make([]float64, 0, 2*n)
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n-1)
+		mag := math.Exp(logMin + t*(logMax-logMin))
+		levels = append(levels, mag, -mag)
+	}
+	return levels
+}
+
+// PlotEquipotentials overlays equipotential contours at the given
+// potential levels on p, using marching squares over the same grid
+// GenerateTestPoints samples.
+//
+// For each grid cell, the 4-bit case index comes from the sign of
+// (V - level) at its four corners; edges with a sign change are
+// linearly interpolated to find the crossing point. The ambiguous
+// saddle cases (5 and 10) are disambiguated using the cell-center
+// average of V.
+func PlotEquipotentials(p *plot.Plot, points []Point, interval float64, levels []float64) {
+	if interval <= 0 {
+		return
+	}
+
+	nx := int(math.Floor((MaxValue-MinValue)/interval+1e-9)) + 1
+	ny := nx
+
+	v := make([][]float64, nx)
+	for i := 0; i < nx; i++ {
+		v[i] = make([]float64, ny)
+		x := MinValue + float64(i)*interval
+		for j := 0; j < ny; j++ {
+			y := MinValue + float64(j)*interval
+			v[i][j] = ElectricPotential(points, x, y)
+		}
+	}
+
+	for _, level := range levels {
+		segments := marchingSquares(v, interval, level)
+		for _, seg := range segments {
+			line, err := plotter.NewLine(plotter.XYs{seg[0], seg[1]})
+			if err != nil {
+				log.Fatalf("could not create contour line: %v", err)
+			}
+			line.LineStyle.Width = 1
+			p.Add(line)
+		}
+	}
+}
+
+// marchingSquares returns one line segment per grid cell that the
+// level crosses, or two for the saddle cases 5 and 10.
+func marchingSquares(v [][]float64, interval, level float64) [][2]plotter.XY {
+	nx, ny := len(v), len(v[0])
+	var segments [][2]plotter.XY
+
+	lerp := func(x0, y0, v0, x1, y1, v1 float64) plotter.XY {
+		t := (level - v0) / (v1 - v0)
+		return plotter.XY{X: x0 + t*(x1-x0), Y: y0 + t*(y1-y0)}
+	}
+
+	for i := 0; i < nx-1; i++ {
+		for j := 0; j < ny-1; j++ {
+			x0 := MinValue + float64(i)*interval
+			y0 := MinValue + float64(j)*interval
+			x1 := x0 + interval
+			y1 := y0 + interval
+
+			// Corners in the conventional marching-squares order:
+			// bottom-left, bottom-right, top-right, top-left.
+			vBL, vBR, vTR, vTL := v[i][j], v[i+1][j], v[i+1][j+1], v[i][j+1]
+
+			bit := func(c float64) int {
+				if c > level {
+					return 1
+				}
+				return 0
+			}
+			c := bit(vBL)<<0 | bit(vBR)<<1 | bit(vTR)<<2 | bit(vTL)<<3
+
+			if c == 0 || c == 15 {
+				continue
+			}
+
+			bottom := lerp(x0, y0, vBL, x1, y0, vBR)
+			right := lerp(x1, y0, vBR, x1, y1, vTR)
+			top := lerp(x1, y1, vTR, x0, y1, vTL)
+			left := lerp(x0, y1, vTL, x0, y0, vBL)
+
+			center := (vBL + vBR + vTR + vTL) / 4
+
+			switch c {
+			case 1, 14:
+				segments = append(segments, [2]plotter.XY{left, bottom})
+			case 2, 13:
+				segments = append(segments, [2]plotter.XY{bottom, right})
+			case 3, 12:
+				segments = append(segments, [2]plotter.XY{left, right})
+			case 4, 11:
+				segments = append(segments, [2]plotter.XY{right, top})
+			case 6, 9:
+				segments = append(segments, [2]plotter.XY{bottom, top})
+			case 7, 8:
+				segments = append(segments, [2]plotter.XY{left, top})
+			case 5:
+				if center > level {
+					segments = append(segments, [2]plotter.XY{left, top}, [2]plotter.XY{bottom, right})
+				} else {
+					segments = append(segments, [2]plotter.XY{left, bottom}, [2]plotter.XY{right, top})
+				}
+			case 10:
+				if center > level {
+					segments = append(segments, [2]plotter.XY{left, bottom}, [2]plotter.XY{right, top})
+				} else {
+					segments = append(segments, [2]plotter.XY{left, top}, [2]plotter.XY{bottom, right})
+				}
+			}
+		}
+	}
+
+	return segments
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestElectricPotentialSingleCharge(t *testing.T) {
+	points := []Point{{X: 0, Y: 0, Charge: 1}}
+
+	got := ElectricPotential(points, 1, 0)
+	want := coulombConstant
+	if math.Abs(got-want) > 1e-3 {
+		t.Errorf("ElectricPotential() = %v, want %v", got, want)
+	}
+}
+
+func TestElectricPotentialSuperposition(t *testing.T) {
+	points := []Point{
+		{X: -1, Y: 0, Charge: 1},
+		{X: 1, Y: 0, Charge: -1},
+	}
+
+	got := ElectricPotential(points, 0, 0)
+	if math.Abs(got) > 1e-6 {
+		t.Errorf("ElectricPotential() = %v, want ~0 by symmetry", got)
+	}
+}
+
+func TestMarchingSquaresNoCrossing(t *testing.T) {
+	origMin := MinValue
+	defer func() { MinValue = origMin }()
+	MinValue = 0
+
+	v := [][]float64{{0, 0}, {0, 0}}
+	segs := marchingSquares(v, 1, 5)
+	if len(segs) != 0 {
+		t.Errorf("marchingSquares() returned %d segments, want 0", len(segs))
+	}
+}
+
+func TestMarchingSquaresSimpleCrossing(t *testing.T) {
+	origMin := MinValue
+	defer func() { MinValue = origMin }()
+	MinValue = 0
+
+	// v[i][j] indexes (x, y); corners bottom-left=0, bottom-right=0,
+	// top-right=2, top-left=2, so level=1 crosses the left and right
+	// edges of the single cell, each at the edge midpoint.
+	v := [][]float64{{0, 2}, {0, 2}}
+	segs := marchingSquares(v, 1, 1)
+	if len(segs) != 1 {
+		t.Fatalf("marchingSquares() returned %d segments, want 1", len(segs))
+	}
+
+	seg := segs[0]
+	xs := []float64{seg[0].X, seg[1].X}
+	if !(almostOneOf(xs[0], 0, 1) && almostOneOf(xs[1], 0, 1) && xs[0] != xs[1]) {
+		t.Errorf("segment endpoints = %+v, want one on x=0 and one on x=1", seg)
+	}
+	for _, p := range seg {
+		if math.Abs(p.Y-0.5) > 1e-9 {
+			t.Errorf("segment endpoint %+v, want Y=0.5", p)
+		}
+	}
+}
+
+func almostOneOf(v float64, opts ...float64) bool {
+	for _, o := range opts {
+		if math.Abs(v-o) < 1e-9 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMarchingSquaresSaddleDisambiguation(t *testing.T) {
+	origMin := MinValue
+	defer func() { MinValue = origMin }()
+	MinValue = 0
+
+	// Case 5: high corners at bottom-left and top-right, low at the
+	// other two, with the cell-center average (1.5) above the level so
+	// the two crossing segments cut off the low corners (left-top and
+	// bottom-right), leaving the high corners connected through the
+	// center.
+	v := [][]float64{{3, 0}, {0, 3}}
+	segs := marchingSquares(v, 1, 1)
+	if len(segs) != 2 {
+		t.Fatalf("marchingSquares() returned %d segments, want 2", len(segs))
+	}
+}
@@ -0,0 +1,207 @@
+// Package materials extends the point-charge-in-vacuum model with
+// dielectric and conductor regions.
+package materials
+
+import "math"
+
+// Bounds is an axis-aligned rectangle used to describe a region's
+// extent.
+type Bounds struct {
+	XMin, YMin, XMax, YMax float64
+}
+
+// Contains reports whether (x, y) falls within b, inclusive of edges.
+func (b Bounds) Contains(x, y float64) bool {
+	return x >= b.XMin && x <= b.XMax && y >= b.YMin && y <= b.YMax
+}
+
+// Region is a material region that perturbs the field evaluated
+// inside it.
+type Region interface {
+	Bounds() Bounds
+}
+
+// DielectricRect is a rectangular region of linear dielectric with
+// relative permittivity EpsilonR; the field inside is scaled by
+// 1/EpsilonR.
+type DielectricRect struct {
+	Rect     Bounds
+	EpsilonR float64
+}
+
+// Bounds implements Region.
+func (d DielectricRect) Bounds() Bounds { return d.Rect }
+
+// ConductorRect is a rectangular region of ideal conductor: zero field
+// inside, and zero tangential field on its surface once Grid.Relax has
+// been run over the enclosing potential grid.
+type ConductorRect struct {
+	Rect Bounds
+}
+
+// Bounds implements Region.
+func (c ConductorRect) Bounds() Bounds { return c.Rect }
+
+// FieldScale returns the factor to apply to a vacuum-field sample at
+// (x, y): 0 inside a conductor, 1/EpsilonR inside a dielectric, and 1
+// everywhere else. When regions overlap, the first match in order
+// wins.
+func FieldScale(regions []Region, x, y float64) float64 {
+	for _, r := range regions {
+		switch region := r.(type) {
+		case ConductorRect:
+			if region.Rect.Contains(x, y) {
+				return 0
+			}
+		case DielectricRect:
+			if region.Rect.Contains(x, y) {
+				return 1 / region.EpsilonR
+			}
+		}
+	}
+	return 1
+}
+
+// Grid is a 2D sampling of scalar potential on [MinX, MinX+...] x
+// [MinY, MinY+...] at the given interval, used to relax conductor
+// boundary conditions via Gauss-Seidel iteration.
+type Grid struct {
+	Values   [][]float64
+	MinX     float64
+	MinY     float64
+	Interval float64
+}
+
+// NewGrid allocates a Grid of zero potential covering
+// [minX, maxX] x [minY, maxY] at the given sampling interval. Swapped
+// or degenerate bounds (maxX < minX, or an interval too large/small to
+// produce at least one cell) are tolerated rather than producing a
+// negative slice length.
+func NewGrid(minX, minY, maxX, maxY, interval float64) *Grid {
+	if maxX < minX {
+		minX, maxX = maxX, minX
+	}
+	if maxY < minY {
+		minY, maxY = maxY, minY
+	}
+
+	nx := gridSpan(maxX-minX, interval)
+	ny := gridSpan(maxY-minY, interval)
+
+	values := make([][]float64, nx)
+	for i := range values {
+		values[i] = make([]float64, ny)
+	}
+
+	return &Grid{Values: values, MinX: minX, MinY: minY, Interval: interval}
+}
+
+// gridSpan returns the number of grid cells spanning extent at the
+// given interval, never less than 1.
+func gridSpan(extent, interval float64) int {
+	if interval <= 0 {
+		return 1
+	}
+	n := int(math.Round(extent/interval)) + 1
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// index returns the nearest grid cell to (x, y), clamped to bounds.
+func (g *Grid) index(x, y float64) (int, int) {
+	nx, ny := len(g.Values), len(g.Values[0])
+	i := int(math.Round((x - g.MinX) / g.Interval))
+	j := int(math.Round((y - g.MinY) / g.Interval))
+	if i < 0 {
+		i = 0
+	} else if i >= nx {
+		i = nx - 1
+	}
+	if j < 0 {
+		j = 0
+	} else if j >= ny {
+		j = ny - 1
+	}
+	return i, j
+}
+
+// At samples the grid at the nearest node to (x, y).
+func (g *Grid) At(x, y float64) float64 {
+	i, j := g.index(x, y)
+	return g.Values[i][j]
+}
+
+// Gradient estimates -∇V at (x, y) by central differences, giving the
+// electric field implied by the relaxed potential grid.
+func (g *Grid) Gradient(x, y float64) (ex, ey float64) {
+	h := g.Interval
+	ex = -(g.At(x+h, y) - g.At(x-h, y)) / (2 * h)
+	ey = -(g.At(x, y+h) - g.At(x, y-h)) / (2 * h)
+	return ex, ey
+}
+
+// Relax drives g.Values, which on entry holds the potential sourced by
+// the scene's charges, toward a solution that also satisfies the
+// conductor boundary condition (0V on and inside every conductor),
+// using Gauss-Seidel iteration. Averaging neighbors directly solves
+// Laplace's equation, not Poisson's, so sweeping the charge potential
+// in place would smear it into a discrete harmonic function of the
+// outer boundary and erase it everywhere in the interior - not just
+// perturb it near the conductors. Instead this solves for a harmonic
+// correction term with boundary condition -V on each conductor cell
+// (so V+correction is 0 there, outer ring included) and 0 on the rest
+// of the grid's outer ring (so the field far from every conductor is
+// left alone), then adds the
+// correction back onto the original charge potential.
+func (g *Grid) Relax(conductors []ConductorRect, iterations int) {
+	nx, ny := len(g.Values), len(g.Values[0])
+
+	isConductor := make([][]bool, nx)
+	for i := range isConductor {
+		isConductor[i] = make([]bool, ny)
+		x := g.MinX + float64(i)*g.Interval
+		for j := range isConductor[i] {
+			y := g.MinY + float64(j)*g.Interval
+			for _, c := range conductors {
+				if c.Rect.Contains(x, y) {
+					isConductor[i][j] = true
+					break
+				}
+			}
+		}
+	}
+
+	source := make([][]float64, nx)
+	for i := range source {
+		source[i] = append([]float64(nil), g.Values[i]...)
+	}
+	correction := make([][]float64, nx)
+	for i := range correction {
+		correction[i] = make([]float64, ny)
+		for j := range correction[i] {
+			if isConductor[i][j] {
+				correction[i][j] = -source[i][j]
+			}
+		}
+	}
+
+	for iter := 0; iter < iterations; iter++ {
+		for i := 1; i < nx-1; i++ {
+			for j := 1; j < ny-1; j++ {
+				if isConductor[i][j] {
+					correction[i][j] = -source[i][j]
+					continue
+				}
+				correction[i][j] = (correction[i-1][j] + correction[i+1][j] + correction[i][j-1] + correction[i][j+1]) / 4
+			}
+		}
+	}
+
+	for i := 0; i < nx; i++ {
+		for j := 0; j < ny; j++ {
+			g.Values[i][j] = source[i][j] + correction[i][j]
+		}
+	}
+}
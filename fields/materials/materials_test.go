@@ -0,0 +1,77 @@
+package materials
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGridRelaxZeroesConductorInterior(t *testing.T) {
+	g := NewGrid(0, 0, 10, 10, 1)
+	for i := range g.Values {
+		for j := range g.Values[i] {
+			g.Values[i][j] = float64(i + j) // arbitrary nonzero starting potential
+		}
+	}
+
+	conductor := ConductorRect{Rect: Bounds{XMin: 4, YMin: 4, XMax: 6, YMax: 6}}
+	g.Relax([]ConductorRect{conductor}, 200)
+
+	for i := range g.Values {
+		x := g.MinX + float64(i)*g.Interval
+		for j := range g.Values[i] {
+			y := g.MinY + float64(j)*g.Interval
+			if conductor.Rect.Contains(x, y) && g.Values[i][j] != 0 {
+				t.Errorf("Values[%d][%d] at (%v, %v) = %v, want 0 inside conductor", i, j, x, y, g.Values[i][j])
+			}
+		}
+	}
+}
+
+func TestNewGridHandlesSwappedBounds(t *testing.T) {
+	g := NewGrid(10, 10, 0, 0, 1)
+	if len(g.Values) == 0 || len(g.Values[0]) == 0 {
+		t.Fatalf("NewGrid() with swapped bounds produced an empty grid: %dx%d", len(g.Values), len(g.Values[0]))
+	}
+	if g.MinX != 0 || g.MinY != 0 {
+		t.Errorf("NewGrid() with swapped bounds: MinX=%v MinY=%v, want 0, 0", g.MinX, g.MinY)
+	}
+}
+
+func TestGridRelaxPreservesDistantChargePotential(t *testing.T) {
+	// A single charge far from a small, unrelated conductor should keep
+	// (almost) its unperturbed potential at a point far from both: the
+	// conductor's own boundary correction should decay with distance,
+	// not erase the charge's field everywhere in the grid.
+	const coulombConstant = 8.987551787e9
+	g := NewGrid(0, 0, 20, 20, 1)
+	chargeX, chargeY := 10.0, 10.0
+	for i := range g.Values {
+		x := g.MinX + float64(i)*g.Interval
+		for j := range g.Values[i] {
+			y := g.MinY + float64(j)*g.Interval
+			dx, dy := x-chargeX, y-chargeY
+			r := dx*dx + dy*dy
+			if r == 0 {
+				continue
+			}
+			g.Values[i][j] = coulombConstant / math.Sqrt(r)
+		}
+	}
+
+	want := g.At(10, 5)
+
+	conductor := ConductorRect{Rect: Bounds{XMin: 0, YMin: 0, XMax: 1, YMax: 1}}
+	g.Relax([]ConductorRect{conductor}, 200)
+
+	got := g.At(10, 5)
+	if relErr := (got - want) / want; relErr < -0.01 || relErr > 0.01 {
+		t.Errorf("Relax() perturbed distant potential by %.1f%%: got %v, want ~%v", relErr*100, got, want)
+	}
+}
+
+func TestNewGridHandlesZeroInterval(t *testing.T) {
+	g := NewGrid(0, 0, 10, 10, 0)
+	if len(g.Values) == 0 || len(g.Values[0]) == 0 {
+		t.Fatalf("NewGrid() with a zero interval produced an empty grid: %dx%d", len(g.Values), len(g.Values[0]))
+	}
+}
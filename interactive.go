@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// arrowTooltip is the per-arrow hover data collected while a scene is
+// plotted: its position, field magnitude, direction, and the fraction
+// of the canvas (FracX, FracY; origin top-left, matching DOM/SVG
+// coordinates) it was actually drawn at, per plot.Plot.Transforms -
+// this accounts for the title/axis-label/tick margins gonum reserves
+// around the data rectangle, which a client-side linear map from
+// [min_value, max_value] would miss.
+//
+// Tooltips are matched by nearest-neighbor against this metadata
+// rather than by tagging each plotted element with a data attribute:
+// plotter.Line and plotter.Scatter draw straight to a vg.Canvas and
+// don't expose a hook to attach an id to the SVG path/shape they
+// emit, so per-element tagging would mean forking vg/vgsvg's renderer
+// rather than using the plotter API as intended. nearestTooltip below
+// is the Go mirror of the client-side lookup (see interactiveTemplate)
+// and is what gets test coverage, since the lookup itself runs in the
+// exported HTML's JavaScript.
+type arrowTooltip struct {
+	X, Y         float64
+	Magnitude    float64
+	AngleDeg     float64
+	FracX, FracY float64
+}
+
+func (a arrowTooltip) frac() (x, y float64) { return a.FracX, a.FracY }
+
+// chargeTooltip is the per-charge hover data: its position, value, and
+// canvas fraction (see arrowTooltip).
+type chargeTooltip struct {
+	X, Y, Charge float64
+	FracX, FracY float64
+}
+
+func (c chargeTooltip) frac() (x, y float64) { return c.FracX, c.FracY }
+
+// fracTooltip is implemented by arrowTooltip and chargeTooltip so
+// nearestTooltip can operate on either kind of hover metadata.
+type fracTooltip interface {
+	frac() (x, y float64)
+}
+
+// nearestTooltip finds the item in items whose canvas position -
+// (FracX, FracY) scaled by the rendered SVG's actual width/height -
+// is closest to (px, py) in canvas-pixel space, along with that
+// distance. It mirrors the "nearest" function embedded in
+// interactiveTemplate's JavaScript below; keep the two in sync if the
+// hit-testing logic changes.
+func nearestTooltip[T fracTooltip](items []T, px, py, width, height float64) (best T, dist float64) {
+	dist = math.Inf(1)
+	for _, item := range items {
+		x, y := item.frac()
+		dx := x*width - px
+		dy := y*height - py
+		if d := math.Hypot(dx, dy); d < dist {
+			dist = d
+			best = item
+		}
+	}
+	return best, dist
+}
+
+// ExportInteractive renders scene as an SVG (via gonum's vgsvg canvas)
+// wrapped in a small HTML shell with pan/zoom and hover tooltips,
+// turning the one-shot PNG into something explorable in a browser.
+// Each arrow and charge gets an entry in the embedded metadata so the
+// page's JavaScript can report (x, y, |E|, angle) or (q, position) on
+// hover without round-tripping to a server. The page always shows the
+// arrow grid: scene.Mode and the contour settings are not applied.
+func (scene Scene) ExportInteractive(path string) error {
+	MinValue = scene.MinValue
+	MaxValue = scene.MaxValue
+
+	interval := scene.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	testPoints := GenerateTestPoints(interval, scene.Charges)
+
+	cmap, err := ParseColormap(scene.Colormap)
+	if err != nil {
+		return err
+	}
+
+	p := plot.New()
+	p.Title.Text = "Electric Field Vectors"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	field := regionAdjustedField(scene, interval, fieldEvaluator(scene.Charges))
+
+	PlotRegions(p, scene)
+	PlotPoints(p, scene.Charges, testPoints)
+	PlotElectricFieldLines(p, field, testPoints, cmap)
+
+	p.X.Min = MinValue
+	p.X.Max = MaxValue
+	p.Y.Min = MinValue
+	p.Y.Max = MaxValue
+
+	width, height := scene.Width, scene.Height
+	if width == 0 {
+		width = 8 * vg.Inch
+	}
+	if height == 0 {
+		height = 8 * vg.Inch
+	}
+
+	canvas := vgsvg.New(width, height)
+	c := draw.New(canvas)
+	p.Draw(c)
+
+	var svgBuf bytes.Buffer
+	if _, err := canvas.WriteTo(&svgBuf); err != nil {
+		return fmt.Errorf("rendering svg: %w", err)
+	}
+
+	// p.Transforms gives the exact data->canvas mapping plot.Draw used,
+	// margins (title, axis labels, ticks) included, so tooltip hit
+	// testing lines up with what was actually drawn instead of
+	// approximating it with a raw [min_value, max_value] fraction.
+	xTransform, yTransform := p.Transforms(&c)
+	toFrac := func(x, y float64) (float64, float64) {
+		fx := float64(xTransform(x)) / float64(width)
+		fy := 1 - float64(yTransform(y))/float64(height)
+		return fx, fy
+	}
+
+	meta, err := json.Marshal(interactiveMeta(scene, testPoints, field, toFrac))
+	if err != nil {
+		return fmt.Errorf("encoding tooltip metadata: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %q: %w", path, err)
+	}
+	defer f.Close()
+
+	return interactiveTemplate.Execute(f, struct {
+		SVG  template.HTML
+		Meta template.JS
+	}{
+		SVG:  template.HTML(svgBuf.String()),
+		Meta: template.JS(meta),
+	})
+}
+
+func interactiveMeta(scene Scene, testPoints []TestPoint, field func(x, y float64) (float64, float64), toFrac func(x, y float64) (float64, float64)) struct {
+	Arrows  []arrowTooltip  `json:"arrows"`
+	Charges []chargeTooltip `json:"charges"`
+} {
+	arrows := make([]arrowTooltip, 0, len(testPoints))
+	for _, tp := range testPoints {
+		ex, ey := field(tp.X, tp.Y)
+		fracX, fracY := toFrac(tp.X, tp.Y)
+		arrows = append(arrows, arrowTooltip{
+			X:         tp.X,
+			Y:         tp.Y,
+			Magnitude: math.Hypot(ex, ey),
+			AngleDeg:  math.Atan2(ey, ex) * 180 / math.Pi,
+			FracX:     fracX,
+			FracY:     fracY,
+		})
+	}
+
+	charges := make([]chargeTooltip, 0, len(scene.Charges))
+	for _, c := range scene.Charges {
+		fracX, fracY := toFrac(c.X, c.Y)
+		charges = append(charges, chargeTooltip{X: c.X, Y: c.Y, Charge: c.Charge, FracX: fracX, FracY: fracY})
+	}
+
+	return struct {
+		Arrows  []arrowTooltip  `json:"arrows"`
+		Charges []chargeTooltip `json:"charges"`
+	}{Arrows: arrows, Charges: charges}
+}
+
+var interactiveTemplate = template.Must(template.New("interactive").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Electric Field (interactive)</title>
+<style>
+  body { margin: 0; font-family: sans-serif; }
+  #viewport { width: 100%; height: 100vh; overflow: hidden; cursor: grab; }
+  #stage { transform-origin: 0 0; }
+  #tooltip {
+    position: fixed; display: none; pointer-events: none;
+    background: rgba(0,0,0,0.8); color: #fff; padding: 4px 8px;
+    border-radius: 4px; font-size: 12px; white-space: pre; z-index: 1;
+  }
+</style>
+</head>
+<body>
+<div id="viewport"><div id="stage">{{.SVG}}</div></div>
+<div id="tooltip"></div>
+<script>
+  const meta = {{.Meta}};
+  const viewport = document.getElementById('viewport');
+  const stage = document.getElementById('stage');
+  const tooltip = document.getElementById('tooltip');
+
+  // Pan/zoom: drag to pan, wheel to zoom.
+  let scale = 1, panX = 0, panY = 0, dragging = false, lastX = 0, lastY = 0;
+  function applyTransform() {
+    stage.style.transform = 'translate(' + panX + 'px,' + panY + 'px) scale(' + scale + ')';
+  }
+  viewport.addEventListener('mousedown', e => {
+    dragging = true; lastX = e.clientX; lastY = e.clientY; viewport.style.cursor = 'grabbing';
+  });
+  window.addEventListener('mouseup', () => { dragging = false; viewport.style.cursor = 'grab'; });
+  viewport.addEventListener('wheel', e => {
+    e.preventDefault();
+    scale *= e.deltaY < 0 ? 1.1 : 0.9;
+    applyTransform();
+  }, { passive: false });
+
+  // Tooltips: nearest-neighbor lookup in canvas-pixel space against
+  // the arrow/charge metadata collected while the scene was plotted.
+  // Each item's FracX/FracY is the exact fraction of the rendered
+  // canvas it was drawn at (from plot.Plot.Transforms, so title/axis
+  // margins are already accounted for), scaled here by the SVG's
+  // actual on-screen size. The pick radius is a fixed CSS-pixel
+  // distance rather than a data-space one, so it works the same
+  // whether the scene spans [0, 20] or [0, 2000].
+  const svg = stage.querySelector('svg');
+  const pickRadiusPx = 18;
+  function nearest(list, px, py, rectW, rectH) {
+    let best = null, bestDist = Infinity;
+    for (const item of list) {
+      const dx = item.FracX * rectW - px;
+      const dy = item.FracY * rectH - py;
+      const dist = dx * dx + dy * dy;
+      if (dist < bestDist) { bestDist = dist; best = item; }
+    }
+    return [best, Math.sqrt(bestDist)];
+  }
+  viewport.addEventListener('mousemove', e => {
+    if (dragging) {
+      panX += e.movementX; panY += e.movementY;
+      applyTransform();
+    }
+
+    const rect = svg.getBoundingClientRect();
+    const px = e.clientX - rect.left;
+    const py = e.clientY - rect.top;
+    const [nearestCharge, chargeDist] = nearest(meta.charges, px, py, rect.width, rect.height);
+    const [nearestArrow, arrowDist] = nearest(meta.arrows, px, py, rect.width, rect.height);
+
+    let text = null;
+    if (nearestCharge && chargeDist < pickRadiusPx) {
+      text = 'q=' + nearestCharge.Charge +
+        '\n(x=' + nearestCharge.X.toFixed(2) + ', y=' + nearestCharge.Y.toFixed(2) + ')';
+    } else if (nearestArrow && arrowDist < pickRadiusPx) {
+      text = '|E|=' + nearestArrow.Magnitude.toExponential(2) +
+        '\nangle=' + nearestArrow.AngleDeg.toFixed(1) + ' deg' +
+        '\n(x=' + nearestArrow.X.toFixed(2) + ', y=' + nearestArrow.Y.toFixed(2) + ')';
+    }
+
+    if (text) {
+      tooltip.style.display = 'block';
+      tooltip.style.left = (e.clientX + 12) + 'px';
+      tooltip.style.top = (e.clientY + 12) + 'px';
+      tooltip.textContent = text;
+    } else {
+      tooltip.style.display = 'none';
+    }
+  });
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearestTooltipPicksCloserCharge(t *testing.T) {
+	charges := []chargeTooltip{
+		{X: 1, Y: 1, Charge: 1, FracX: 0.20, FracY: 0.20},
+		{X: 9, Y: 9, Charge: -1, FracX: 0.80, FracY: 0.80},
+	}
+
+	// A cursor near the first charge's canvas position should match it
+	// even though the second is also present.
+	got, dist := nearestTooltip(charges, 0.21*400, 0.19*400, 400, 400)
+	if got != charges[0] {
+		t.Errorf("nearestTooltip() = %+v, want the closer charge %+v", got, charges[0])
+	}
+	if dist > 10 {
+		t.Errorf("nearestTooltip() dist = %v, want a small pixel distance", dist)
+	}
+
+	got, _ = nearestTooltip(charges, 0.79*400, 0.81*400, 400, 400)
+	if got != charges[1] {
+		t.Errorf("nearestTooltip() = %+v, want the closer charge %+v", got, charges[1])
+	}
+}
+
+func TestNearestTooltipDisambiguatesNearbyPoints(t *testing.T) {
+	// Two arrows a few canvas-pixels apart, well within the embedded
+	// template's pickRadiusPx - the lookup must still pick whichever
+	// one is strictly closer, not an arbitrary one.
+	arrows := []arrowTooltip{
+		{X: 5, Y: 5, FracX: 0.500, FracY: 0.500},
+		{X: 5.1, Y: 5, FracX: 0.505, FracY: 0.500},
+	}
+	const width, height = 800.0, 800.0
+
+	cursorX := 0.502 * width
+	got, _ := nearestTooltip(arrows, cursorX, 0.500*height, width, height)
+	if got != arrows[0] {
+		t.Errorf("nearestTooltip() = %+v, want the closer arrow %+v", got, arrows[0])
+	}
+
+	cursorX = 0.504 * width
+	got, _ = nearestTooltip(arrows, cursorX, 0.500*height, width, height)
+	if got != arrows[1] {
+		t.Errorf("nearestTooltip() = %+v, want the closer arrow %+v", got, arrows[1])
+	}
+}
+
+func TestNearestTooltipEmptyList(t *testing.T) {
+	var charges []chargeTooltip
+	_, dist := nearestTooltip(charges, 0, 0, 100, 100)
+	if !math.IsInf(dist, 1) {
+		t.Errorf("nearestTooltip() on an empty list dist = %v, want +Inf", dist)
+	}
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -103,116 +105,106 @@ func PlotPoints(p *plot.Plot, points []Point, testPoints []TestPoint) {
 	}
 }
 
-func PlotElectricFieldLines(p *plot.Plot, points []Point, testPoints []TestPoint) {
-	for _, testPoint := range testPoints {
-		sumEx := 0.0
-		sumEy := 0.0
-		for _, point := range points {
-			ex, ey := CalculateElectricField(point.Charge, point.X, point.Y, testPoint.X, testPoint.Y)
-			sumEx += ex
-			sumEy += ey
-		}
+func main() {
+	scenePath := flag.String("scene", "", "path to a scene file (.json or .csv) to render without prompting")
+	flag.Parse()
 
-		sumNorm := math.Sqrt(sumEx*sumEx + sumEy*sumEy)
+	var scene Scene
+	var err error
 
-		var lineColor color.Color
-		if sumNorm == 0 {
-			lineColor = color.RGBA{R: 0, G: 0, B: 0, A: 0}
-		} else {
-			intensity := uint8(math.Min(255, 255*sumNorm/8.987551787e+07))
-			lineColor = color.RGBA{R: 0, G: intensity, B: 255 - intensity, A: 255}
+	if *scenePath != "" {
+		scene, err = loadScene(*scenePath)
+		if err != nil {
+			log.Fatalf("could not load scene: %v", err)
 		}
-
-		sumEx /= sumNorm
-		sumEy /= sumNorm
-
-		line, err := plotter.NewLine(plotter.XYs{{X: testPoint.X, Y: testPoint.Y}, {X: testPoint.X + sumEx, Y: testPoint.Y + sumEy}})
+	} else {
+		scene, err = promptScene()
 		if err != nil {
-			log.Fatalf("could not create line plot: %v", err)
+			log.Fatalf("could not read scene: %v", err)
 		}
-		// line.LineStyle.Color = color.RGBA{R: 0, G: 0, B: 0, A: 255}
-		line.LineStyle.Color = lineColor
-		p.Add(line)
+	}
+
+	if strings.ToLower(filepath.Ext(scene.OutputPath)) == ".html" {
+		err = scene.ExportInteractive(scene.OutputPath)
+	} else {
+		err = Run(scene)
+	}
+	if err != nil {
+		log.Fatalf("could not render scene: %v", err)
 	}
 }
 
-func main() {
-	fmt.Print("Enter the number of charges:")
+// loadScene picks a loader based on the scene file's extension.
+func loadScene(path string) (Scene, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadSceneJSON(path)
+	case ".csv":
+		return LoadSceneCSV(path)
+	default:
+		return Scene{}, fmt.Errorf("unrecognized scene file extension %q", filepath.Ext(path))
+	}
+}
+
+// promptScene reproduces the original interactive prompt. It is now a
+// thin wrapper that builds a Scene and leaves the actual rendering to
+// Run, so the prompt is just one of several ways to produce a Scene.
+func promptScene() (Scene, error) {
+	scene := DefaultScene()
 	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter the number of charges:")
 	numChargesStr, err := reader.ReadString('\n')
 	if err != nil {
-		log.Fatalf("error reading input: %v", err)
+		return Scene{}, fmt.Errorf("error reading input: %w", err)
 	}
-	numChargesStr = strings.TrimSpace(numChargesStr)
-	numCharges, err := strconv.Atoi(numChargesStr)
+	numCharges, err := strconv.Atoi(strings.TrimSpace(numChargesStr))
 	if err != nil {
-		log.Fatalf("invalid number of charges: %v", err)
+		return Scene{}, fmt.Errorf("invalid number of charges: %w", err)
 	}
 
-	var charges []Point
-
 	for i := 0; i < numCharges; i++ {
 		fmt.Printf("Enter the position (x y) and charge value for charge %d, separated by spaces: ", i+1)
 		inputStr, err := reader.ReadString('\n')
 		if err != nil {
-			log.Fatalf("error reading input: %v", err)
+			return Scene{}, fmt.Errorf("error reading input: %w", err)
 		}
-		inputStr = strings.TrimSpace(inputStr)
-		parts := strings.Fields(inputStr)
+		parts := strings.Fields(strings.TrimSpace(inputStr))
 		if len(parts) != 3 {
-			log.Fatalf("invalid input format")
+			return Scene{}, fmt.Errorf("invalid input format")
 		}
 		x, err := strconv.ParseFloat(parts[0], 64)
 		if err != nil {
-			log.Fatalf("invalid position x: %v", err)
+			return Scene{}, fmt.Errorf("invalid position x: %w", err)
 		}
 		y, err := strconv.ParseFloat(parts[1], 64)
 		if err != nil {
-			log.Fatalf("invalid position y: %v", err)
+			return Scene{}, fmt.Errorf("invalid position y: %w", err)
 		}
 		charge, err := strconv.ParseFloat(parts[2], 64)
 		if err != nil {
-			log.Fatalf("invalid charge value: %v", err)
+			return Scene{}, fmt.Errorf("invalid charge value: %w", err)
 		}
-		charges = append(charges, Point{X: x, Y: y, Charge: charge})
+		scene.Charges = append(scene.Charges, Point{X: x, Y: y, Charge: charge})
 	}
 
 	fmt.Print("Enter the maximum value for the plot: ")
 	maxValueStr, err := reader.ReadString('\n')
 	if err != nil {
-		log.Fatalf("error reading input: %v", err)
+		return Scene{}, fmt.Errorf("error reading input: %w", err)
 	}
-	maxValueStr = strings.TrimSpace(maxValueStr)
-	maxValue, err := strconv.ParseFloat(maxValueStr, 64)
+	maxValue, err := strconv.ParseFloat(strings.TrimSpace(maxValueStr), 64)
 	if err != nil {
-		log.Fatalf("invalid maximum value: %v", err)
+		return Scene{}, fmt.Errorf("invalid maximum value: %w", err)
 	}
-	MaxValue = maxValue
+	scene.MaxValue = maxValue
 
 	fmt.Print("Enter the filename for the final plot (e.g., my_plot.png): ")
 	filename, err := reader.ReadString('\n')
 	if err != nil {
-		log.Fatalf("error reading input: %v", err)
+		return Scene{}, fmt.Errorf("error reading input: %w", err)
 	}
-	filename = strings.TrimSpace(filename)
+	scene.OutputPath = strings.TrimSpace(filename)
 
-	testPoints := GenerateTestPoints(1, charges)
-
-	p := plot.New()
-	p.Title.Text = "Electric Field Vectors"
-	p.X.Label.Text = "X"
-	p.Y.Label.Text = "Y"
-
-	PlotPoints(p, charges, testPoints)
-
-	PlotElectricFieldLines(p, charges, testPoints)
-
-	p.X.Min = MinValue
-	p.X.Max = MaxValue
-	p.Y.Min = MinValue
-	p.Y.Max = MaxValue
-
-	if err := p.Save(8*vg.Inch, 8*vg.Inch, filename); err != nil {
-		log.Fatalf("could not save plot: %v", err)
-	}
+	return scene, nil
 }
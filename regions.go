@@ -0,0 +1,103 @@
+package main
+
+import (
+	"image/color"
+	"log"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+
+	"github.com/mbuitragoc/ElectricFields/fields/materials"
+)
+
+// conductorRelaxIterations is the number of Gauss-Seidel sweeps used
+// to relax the potential grid around conductor regions.
+const conductorRelaxIterations = 200
+
+// regionList returns scene.Dielectrics and scene.Conductors as a
+// single []materials.Region, conductors first so they win ties with
+// overlapping dielectrics in materials.FieldScale.
+func regionList(scene Scene) []materials.Region {
+	regions := make([]materials.Region, 0, len(scene.Conductors)+len(scene.Dielectrics))
+	for _, c := range scene.Conductors {
+		regions = append(regions, c)
+	}
+	for _, d := range scene.Dielectrics {
+		regions = append(regions, d)
+	}
+	return regions
+}
+
+// regionAdjustedField wraps field so that it returns 0 inside
+// conductors and a field scaled by 1/epsilon_r inside dielectrics. If
+// scene has conductors, the field everywhere is instead taken from the
+// gradient of a potential grid relaxed around those conductors, since
+// a conductor's presence perturbs the field outside it too.
+func regionAdjustedField(scene Scene, interval float64, field func(x, y float64) (float64, float64)) func(x, y float64) (float64, float64) {
+	regions := regionList(scene)
+	if len(regions) == 0 {
+		return field
+	}
+
+	if len(scene.Conductors) == 0 {
+		return func(x, y float64) (float64, float64) {
+			ex, ey := field(x, y)
+			scale := materials.FieldScale(regions, x, y)
+			return ex * scale, ey * scale
+		}
+	}
+
+	grid := materials.NewGrid(MinValue, MinValue, MaxValue, MaxValue, interval)
+	for i := range grid.Values {
+		x := grid.MinX + float64(i)*grid.Interval
+		for j := range grid.Values[i] {
+			y := grid.MinY + float64(j)*grid.Interval
+			grid.Values[i][j] = ElectricPotential(scene.Charges, x, y)
+		}
+	}
+	grid.Relax(scene.Conductors, conductorRelaxIterations)
+
+	return func(x, y float64) (float64, float64) {
+		for _, c := range scene.Conductors {
+			if c.Rect.Contains(x, y) {
+				return 0, 0
+			}
+		}
+		ex, ey := grid.Gradient(x, y)
+		scale := materials.FieldScale(regions, x, y)
+		return ex * scale, ey * scale
+	}
+}
+
+// PlotRegions draws conductors as filled gray polygons and dielectrics
+// as translucent shaded rectangles, beneath the field arrows/lines.
+func PlotRegions(p *plot.Plot, scene Scene) {
+	for _, d := range scene.Dielectrics {
+		poly, err := plotter.NewPolygon(rectXYs(d.Rect))
+		if err != nil {
+			log.Fatalf("could not create dielectric region: %v", err)
+		}
+		poly.Color = color.RGBA{R: 100, G: 150, B: 255, A: 60}
+		poly.LineStyle.Width = 0
+		p.Add(poly)
+	}
+
+	for _, c := range scene.Conductors {
+		poly, err := plotter.NewPolygon(rectXYs(c.Rect))
+		if err != nil {
+			log.Fatalf("could not create conductor region: %v", err)
+		}
+		poly.Color = color.RGBA{R: 120, G: 120, B: 120, A: 255}
+		poly.LineStyle.Width = 0
+		p.Add(poly)
+	}
+}
+
+func rectXYs(b materials.Bounds) plotter.XYs {
+	return plotter.XYs{
+		{X: b.XMin, Y: b.YMin},
+		{X: b.XMax, Y: b.YMin},
+		{X: b.XMax, Y: b.YMax},
+		{X: b.XMin, Y: b.YMax},
+	}
+}
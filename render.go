@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+)
+
+// Colormap selects how vector magnitude is mapped to color when
+// rendering field arrows and streamlines.
+type Colormap int
+
+const (
+	ColormapViridis Colormap = iota
+	ColormapPlasma
+	ColormapCoolwarm
+)
+
+// ParseColormap looks up a Colormap by name ("viridis", "plasma",
+// "coolwarm"), defaulting to ColormapViridis on an empty string.
+func ParseColormap(name string) (Colormap, error) {
+	switch name {
+	case "", "viridis":
+		return ColormapViridis, nil
+	case "plasma":
+		return ColormapPlasma, nil
+	case "coolwarm":
+		return ColormapCoolwarm, nil
+	default:
+		return 0, fmt.Errorf("unknown colormap %q", name)
+	}
+}
+
+// colorStop is one control point of a piecewise-linear colormap.
+type colorStop struct {
+	t       float64
+	r, g, b uint8
+}
+
+var colormapStops = map[Colormap][]colorStop{
+	ColormapViridis: {
+		{0.00, 68, 1, 84},
+		{0.25, 59, 82, 139},
+		{0.50, 33, 145, 140},
+		{0.75, 94, 201, 98},
+		{1.00, 253, 231, 37},
+	},
+	ColormapPlasma: {
+		{0.00, 13, 8, 135},
+		{0.25, 126, 3, 168},
+		{0.50, 204, 71, 120},
+		{0.75, 248, 149, 64},
+		{1.00, 240, 249, 33},
+	},
+	ColormapCoolwarm: {
+		{0.00, 59, 76, 192},
+		{0.25, 146, 178, 247},
+		{0.50, 221, 221, 221},
+		{0.75, 244, 150, 123},
+		{1.00, 180, 4, 38},
+	},
+}
+
+// At returns the color for t, a magnitude normalized to [0, 1].
+func (cm Colormap) At(t float64) color.Color {
+	t = math.Min(1, math.Max(0, t))
+	stops := colormapStops[cm]
+
+	for i := 0; i < len(stops)-1; i++ {
+		a, b := stops[i], stops[i+1]
+		if t < a.t || t > b.t {
+			continue
+		}
+		span := b.t - a.t
+		frac := 0.0
+		if span > 0 {
+			frac = (t - a.t) / span
+		}
+		lerp := func(x, y uint8) uint8 {
+			return uint8(float64(x) + frac*(float64(y)-float64(x)))
+		}
+		return color.RGBA{R: lerp(a.r, b.r), G: lerp(a.g, b.g), B: lerp(a.b, b.b), A: 255}
+	}
+
+	last := stops[len(stops)-1]
+	return color.RGBA{R: last.r, G: last.g, B: last.b, A: 255}
+}
+
+// sumField returns the superposed electric field of points at (x, y).
+func sumField(points []Point, x, y float64) (float64, float64) {
+	var ex, ey float64
+	for _, pt := range points {
+		fx, fy := CalculateElectricField(pt.Charge, pt.X, pt.Y, x, y)
+		ex += fx
+		ey += fy
+	}
+	return ex, ey
+}
+
+// fieldEvaluator returns a function that evaluates the superposed
+// field of points at a test point, using direct summation for small
+// charge counts and a Barnes-Hut approximation above
+// barnesHutThreshold, where direct summation stops scaling.
+func fieldEvaluator(points []Point) func(x, y float64) (float64, float64) {
+	if len(points) <= barnesHutThreshold {
+		return func(x, y float64) (float64, float64) {
+			return sumField(points, x, y)
+		}
+	}
+	tree := NewBarnesHutTree(points)
+	return func(x, y float64) (float64, float64) {
+		return CalculateElectricFieldBH(tree, x, y)
+	}
+}
+
+const (
+	minArrowLen   = 0.15
+	maxArrowLen   = 0.85
+	headFraction  = 0.35
+	headSpreadDeg = 150
+)
+
+// arrowLength maps a sample's log-magnitude into an arrow length in
+// [minArrowLen, maxArrowLen] and a color position t in [0, 1], via
+// min-max normalization of logMag against [minLog, maxLog], the range
+// seen across the whole grid. t increases monotonically with logMag.
+func arrowLength(logMag, minLog, maxLog float64) (length, t float64) {
+	if maxLog > minLog {
+		t = (logMag - minLog) / (maxLog - minLog)
+	}
+	return minArrowLen + t*(maxArrowLen-minArrowLen), t
+}
+
+// PlotElectricFieldLines renders field, the superposed field at each
+// test point, as an arrow: length scaled by log(1+|E|) normalized
+// across the grid so both weak and strong regions stay visible,
+// colored by cmap, and capped with a small triangular arrowhead.
+func PlotElectricFieldLines(p *plot.Plot, field func(x, y float64) (float64, float64), testPoints []TestPoint, cmap Colormap) {
+	if len(testPoints) == 0 {
+		return
+	}
+
+	type sample struct {
+		x, y, angle, logMag float64
+	}
+
+	samples := make([]sample, 0, len(testPoints))
+	minLog, maxLog := math.Inf(1), math.Inf(-1)
+
+	for _, tp := range testPoints {
+		ex, ey := field(tp.X, tp.Y)
+		mag := math.Hypot(ex, ey)
+		logMag := math.Log(1 + mag)
+		angle := math.Atan2(ey, ex)
+		if mag == 0 {
+			angle = 0
+		}
+
+		samples = append(samples, sample{x: tp.X, y: tp.Y, angle: angle, logMag: logMag})
+		if logMag < minLog {
+			minLog = logMag
+		}
+		if logMag > maxLog {
+			maxLog = logMag
+		}
+	}
+
+	for _, s := range samples {
+		length, t := arrowLength(s.logMag, minLog, maxLog)
+		lineColor := cmap.At(t)
+
+		tipX := s.x + length*math.Cos(s.angle)
+		tipY := s.y + length*math.Sin(s.angle)
+
+		shaft, err := plotter.NewLine(plotter.XYs{{X: s.x, Y: s.y}, {X: tipX, Y: tipY}})
+		if err != nil {
+			log.Fatalf("could not create line plot: %v", err)
+		}
+		shaft.LineStyle.Color = lineColor
+		p.Add(shaft)
+
+		headLen := length * headFraction
+		spread := headSpreadDeg * math.Pi / 180
+		for _, sign := range [...]float64{1, -1} {
+			headAngle := s.angle + sign*spread
+			headX := tipX + headLen*math.Cos(headAngle)
+			headY := tipY + headLen*math.Sin(headAngle)
+
+			head, err := plotter.NewLine(plotter.XYs{{X: tipX, Y: tipY}, {X: headX, Y: headY}})
+			if err != nil {
+				log.Fatalf("could not create line plot: %v", err)
+			}
+			head.LineStyle.Color = lineColor
+			p.Add(head)
+		}
+	}
+}
+
+// GenerateStreamlineSeeds places n seed points evenly around each
+// positive charge, at the given radius, matching the classical
+// convention that field lines originate at positive charges.
+func GenerateStreamlineSeeds(points []Point, n int, radius float64) []TestPoint {
+	var seeds []TestPoint
+	for _, pt := range points {
+		if pt.Charge <= 0 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			angle := 2 * math.Pi * float64(i) / float64(n)
+			seeds = append(seeds, TestPoint{
+				X: pt.X + radius*math.Cos(angle),
+				Y: pt.Y + radius*math.Sin(angle),
+			})
+		}
+	}
+	return seeds
+}
+
+// maxStreamlineSteps bounds how far a single streamline is traced
+// before integration gives up even if it never leaves bounds or nears
+// a charge (e.g. a closed orbit around a saddle in the field).
+const maxStreamlineSteps = 500
+
+// integrateStreamline traces a single streamline from seed by
+// integrating dx/ds = E/|E| with RK4, stopping after
+// maxStreamlineSteps, on leaving [MinValue, MaxValue], or once the
+// curve passes within proximity of one of points - factored out of
+// PlotStreamlines so the integration itself can be tested without a
+// plot.Plot.
+func integrateStreamline(seed TestPoint, points []Point, field func(x, y float64) (float64, float64), step, proximity float64) plotter.XYs {
+	direction := func(x, y float64) (float64, float64, bool) {
+		ex, ey := field(x, y)
+		norm := math.Hypot(ex, ey)
+		if norm == 0 {
+			return 0, 0, false
+		}
+		return ex / norm, ey / norm, true
+	}
+
+	nearCharge := func(x, y float64) bool {
+		for _, pt := range points {
+			if math.Hypot(x-pt.X, y-pt.Y) < proximity {
+				return true
+			}
+		}
+		return false
+	}
+
+	inBounds := func(x, y float64) bool {
+		return x >= MinValue && x <= MaxValue && y >= MinValue && y <= MaxValue
+	}
+
+	x, y := seed.X, seed.Y
+	path := plotter.XYs{{X: x, Y: y}}
+
+	for i := 0; i < maxStreamlineSteps && inBounds(x, y) && !nearCharge(x, y); i++ {
+		k1x, k1y, ok := direction(x, y)
+		if !ok {
+			break
+		}
+		k2x, k2y, ok := direction(x+0.5*step*k1x, y+0.5*step*k1y)
+		if !ok {
+			break
+		}
+		k3x, k3y, ok := direction(x+0.5*step*k2x, y+0.5*step*k2y)
+		if !ok {
+			break
+		}
+		k4x, k4y, ok := direction(x+step*k3x, y+step*k3y)
+		if !ok {
+			break
+		}
+
+		x += step / 6 * (k1x + 2*k2x + 2*k3x + k4x)
+		y += step / 6 * (k1y + 2*k2y + 2*k3y + k4y)
+		path = append(path, plotter.XY{X: x, Y: y})
+	}
+
+	return path
+}
+
+// PlotStreamlines integrates dx/ds = E/|E| from each seed using RK4,
+// producing continuous field-line curves rather than a discrete arrow
+// grid. Integration stops after maxStreamlineSteps, on leaving
+// [MinValue,MaxValue], or once the curve passes within interval/2 of a
+// charge.
+func PlotStreamlines(p *plot.Plot, points []Point, field func(x, y float64) (float64, float64), interval float64, seeds []TestPoint, cmap Colormap) {
+	step := interval / 4
+	proximity := interval / 2
+
+	for _, seed := range seeds {
+		path := integrateStreamline(seed, points, field, step, proximity)
+		if len(path) < 2 {
+			continue
+		}
+
+		line, err := plotter.NewLine(path)
+		if err != nil {
+			log.Fatalf("could not create streamline plot: %v", err)
+		}
+		line.LineStyle.Color = cmap.At(0.5)
+		p.Add(line)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestColormapAtInterpolatesBetweenStops(t *testing.T) {
+	stops := colormapStops[ColormapViridis]
+	first, last := stops[0], stops[len(stops)-1]
+
+	if got := ColormapViridis.At(0); got != (color.RGBA{R: first.r, G: first.g, B: first.b, A: 255}) {
+		t.Errorf("At(0) = %+v, want the first stop's color %+v", got, first)
+	}
+	if got := ColormapViridis.At(1); got != (color.RGBA{R: last.r, G: last.g, B: last.b, A: 255}) {
+		t.Errorf("At(1) = %+v, want the last stop's color %+v", got, last)
+	}
+
+	// Halfway between the two stops bracketing t should land on their
+	// average (truncated to an integer channel value), since the lerp
+	// is linear in each channel.
+	a, b := stops[0], stops[1]
+	avg := func(x, y uint8) uint8 { return uint8((int(x) + int(y)) / 2) }
+	halfway := (a.t + b.t) / 2
+	if got := ColormapViridis.At(halfway); got != (color.RGBA{R: avg(a.r, b.r), G: avg(a.g, b.g), B: avg(a.b, b.b), A: 255}) {
+		t.Errorf("At(%v) = %+v, want the average of stops %+v and %+v", halfway, got, a, b)
+	}
+
+	// A t that is itself a stop lands on that stop's color exactly.
+	mid := colormapStops[ColormapViridis][2] // t=0.50 is already a stop
+	if got := ColormapViridis.At(0.50); got != (color.RGBA{R: mid.r, G: mid.g, B: mid.b, A: 255}) {
+		t.Errorf("At(0.50) = %+v, want the t=0.50 stop %+v", got, mid)
+	}
+}
+
+func TestColormapAtClampsOutOfRangeInput(t *testing.T) {
+	stops := colormapStops[ColormapPlasma]
+	first, last := stops[0], stops[len(stops)-1]
+
+	if got := ColormapPlasma.At(-5); got != (color.RGBA{R: first.r, G: first.g, B: first.b, A: 255}) {
+		t.Errorf("At(-5) = %+v, want clamped to the first stop %+v", got, first)
+	}
+	if got := ColormapPlasma.At(5); got != (color.RGBA{R: last.r, G: last.g, B: last.b, A: 255}) {
+		t.Errorf("At(5) = %+v, want clamped to the last stop %+v", got, last)
+	}
+}
+
+func TestArrowLengthMonotonicWithLogMagnitude(t *testing.T) {
+	const minLog, maxLog = 0.0, 10.0
+	prevLength, prevT := -1.0, -1.0
+	for _, logMag := range []float64{0, 1, 2.5, 5, 7.5, 10} {
+		length, frac := arrowLength(logMag, minLog, maxLog)
+		if length < minArrowLen-1e-9 || length > maxArrowLen+1e-9 {
+			t.Errorf("arrowLength(%v) length = %v, want within [%v, %v]", logMag, length, minArrowLen, maxArrowLen)
+		}
+		if length < prevLength-1e-9 {
+			t.Errorf("arrowLength(%v) length = %v, want >= previous length %v (monotonic in logMag)", logMag, length, prevLength)
+		}
+		if frac < prevT-1e-9 {
+			t.Errorf("arrowLength(%v) t = %v, want >= previous t %v (monotonic in logMag)", logMag, frac, prevT)
+		}
+		prevLength, prevT = length, frac
+	}
+}
+
+func TestArrowLengthFlatRangeUsesMinLength(t *testing.T) {
+	length, frac := arrowLength(5, 5, 5)
+	if length != minArrowLen {
+		t.Errorf("arrowLength() with minLog==maxLog length = %v, want %v", length, minArrowLen)
+	}
+	if frac != 0 {
+		t.Errorf("arrowLength() with minLog==maxLog t = %v, want 0", frac)
+	}
+}
+
+func TestIntegrateStreamlineFollowsFieldAwayFromSeed(t *testing.T) {
+	// A uniform field blowing in +x should produce a path that moves
+	// steadily away from the seed in that direction until it leaves
+	// bounds, terminating well under maxStreamlineSteps.
+	origMin, origMax := MinValue, MaxValue
+	MinValue, MaxValue = 0, 20
+	defer func() { MinValue, MaxValue = origMin, origMax }()
+
+	uniformField := func(x, y float64) (float64, float64) { return 1, 0 }
+	path := integrateStreamline(TestPoint{X: 1, Y: 10}, nil, uniformField, 0.5, 0.1)
+
+	if len(path) < 2 {
+		t.Fatalf("integrateStreamline() produced %d points, want a multi-point path", len(path))
+	}
+	if len(path) >= maxStreamlineSteps {
+		t.Errorf("integrateStreamline() ran the full %d steps, want it to leave bounds first", maxStreamlineSteps)
+	}
+	last := path[len(path)-1]
+	if last.X < MaxValue {
+		t.Errorf("integrateStreamline() last point = %+v, want X at or beyond MaxValue=%v", last, MaxValue)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].X < path[i-1].X-1e-9 {
+			t.Errorf("integrateStreamline() path[%d].X = %v < path[%d].X = %v, want monotonically increasing X in a uniform +x field", i, path[i].X, i-1, path[i-1].X)
+		}
+	}
+}
+
+func TestIntegrateStreamlineStopsNearCharge(t *testing.T) {
+	origMin, origMax := MinValue, MaxValue
+	MinValue, MaxValue = 0, 20
+	defer func() { MinValue, MaxValue = origMin, origMax }()
+
+	// Field lines run from positive to negative charges; a seed just
+	// off the positive charge should flow along +x into the negative
+	// one and terminate once it's within proximity of it.
+	positive := Point{X: 0, Y: 10, Charge: 1}
+	negative := Point{X: 15, Y: 10, Charge: -1}
+	points := []Point{positive, negative}
+	field := func(x, y float64) (float64, float64) {
+		return sumField(points, x, y)
+	}
+	path := integrateStreamline(TestPoint{X: 1, Y: 10}, points, field, 0.5, 1)
+
+	last := path[len(path)-1]
+	if math.Hypot(last.X-negative.X, last.Y-negative.Y) > 1.5 {
+		t.Errorf("integrateStreamline() stopped at %+v, want it to terminate near the negative charge at (%v, %v)", last, negative.X, negative.Y)
+	}
+}
+
+func TestIntegrateStreamlineStopsOnZeroField(t *testing.T) {
+	origMin, origMax := MinValue, MaxValue
+	MinValue, MaxValue = 0, 20
+	defer func() { MinValue, MaxValue = origMin, origMax }()
+
+	zeroField := func(x, y float64) (float64, float64) { return 0, 0 }
+	path := integrateStreamline(TestPoint{X: 5, Y: 5}, nil, zeroField, 0.5, 0.1)
+
+	if len(path) != 1 {
+		t.Errorf("integrateStreamline() with zero field produced %d points, want 1 (no direction to move)", len(path))
+	}
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+
+	"github.com/mbuitragoc/ElectricFields/fields/materials"
+)
+
+// Scene describes everything needed to render a field plot without
+// prompting on stdin: the charges, the plot bounds, the sampling
+// interval, and the output image settings.
+type Scene struct {
+	Charges    []Point
+	MinValue   float64
+	MaxValue   float64
+	Interval   float64
+	OutputPath string
+	Width      vg.Length
+	Height     vg.Length
+	DPI        float64
+	Mode       string // "arrows" (default) or "streamlines"
+	Colormap   string // "viridis" (default), "plasma", or "coolwarm"
+
+	// ContourLevels are the potential values to draw equipotentials at.
+	// If empty and ContourCount > 0, levels are auto-picked by
+	// AutoContourLevels instead.
+	ContourLevels []float64
+	ContourCount  int
+
+	Dielectrics []materials.DielectricRect
+	Conductors  []materials.ConductorRect
+}
+
+// DefaultScene mirrors the values the original interactive prompt used
+// when nothing more specific is supplied.
+func DefaultScene() Scene {
+	return Scene{
+		MinValue:   0,
+		MaxValue:   20,
+		Interval:   1,
+		OutputPath: "field.png",
+		Width:      8 * vg.Inch,
+		Height:     8 * vg.Inch,
+		DPI:        72,
+		Mode:       "arrows",
+		Colormap:   "viridis",
+	}
+}
+
+type sceneJSON struct {
+	Charges []struct {
+		X      float64 `json:"x"`
+		Y      float64 `json:"y"`
+		Charge float64 `json:"charge"`
+	} `json:"charges"`
+	MinValue      float64   `json:"min_value"`
+	MaxValue      float64   `json:"max_value"`
+	Interval      float64   `json:"interval"`
+	OutputPath    string    `json:"output_path"`
+	WidthIn       float64   `json:"width_in"`
+	HeightIn      float64   `json:"height_in"`
+	DPI           float64   `json:"dpi"`
+	Mode          string    `json:"mode"`
+	Colormap      string    `json:"colormap"`
+	ContourLevels []float64 `json:"contour_levels"`
+	ContourCount  int       `json:"contour_count"`
+	Dielectrics   []struct {
+		XMin     float64 `json:"x_min"`
+		YMin     float64 `json:"y_min"`
+		XMax     float64 `json:"x_max"`
+		YMax     float64 `json:"y_max"`
+		EpsilonR float64 `json:"epsilon_r"`
+	} `json:"dielectrics"`
+	Conductors []struct {
+		XMin float64 `json:"x_min"`
+		YMin float64 `json:"y_min"`
+		XMax float64 `json:"x_max"`
+		YMax float64 `json:"y_max"`
+	} `json:"conductors"`
+}
+
+// LoadSceneJSON reads a Scene from a JSON file shaped like:
+//
+//	{
+//	  "charges": [{"x": 5, "y": 5, "charge": 1}],
+//	  "min_value": 0,
+//	  "max_value": 20,
+//	  "interval": 1,
+//	  "output_path": "field.png",
+//	  "width_in": 8,
+//	  "height_in": 8,
+//	  "dpi": 72
+//	}
+func LoadSceneJSON(path string) (Scene, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scene{}, fmt.Errorf("reading scene %q: %w", path, err)
+	}
+
+	var raw sceneJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Scene{}, fmt.Errorf("parsing scene %q: %w", path, err)
+	}
+
+	scene := DefaultScene()
+	for _, c := range raw.Charges {
+		scene.Charges = append(scene.Charges, Point{X: c.X, Y: c.Y, Charge: c.Charge})
+	}
+	scene.MinValue = raw.MinValue
+	if raw.MaxValue != 0 {
+		scene.MaxValue = raw.MaxValue
+	}
+	if raw.Interval != 0 {
+		scene.Interval = raw.Interval
+	}
+	if raw.OutputPath != "" {
+		scene.OutputPath = raw.OutputPath
+	}
+	if raw.WidthIn != 0 {
+		scene.Width = vg.Length(raw.WidthIn) * vg.Inch
+	}
+	if raw.HeightIn != 0 {
+		scene.Height = vg.Length(raw.HeightIn) * vg.Inch
+	}
+	if raw.DPI != 0 {
+		scene.DPI = raw.DPI
+	}
+	if raw.Mode != "" {
+		scene.Mode = raw.Mode
+	}
+	if raw.Colormap != "" {
+		scene.Colormap = raw.Colormap
+	}
+	scene.ContourLevels = raw.ContourLevels
+	scene.ContourCount = raw.ContourCount
+
+	for _, d := range raw.Dielectrics {
+		scene.Dielectrics = append(scene.Dielectrics, materials.DielectricRect{
+			Rect:     materials.Bounds{XMin: d.XMin, YMin: d.YMin, XMax: d.XMax, YMax: d.YMax},
+			EpsilonR: d.EpsilonR,
+		})
+	}
+	for _, c := range raw.Conductors {
+		scene.Conductors = append(scene.Conductors, materials.ConductorRect{
+			Rect: materials.Bounds{XMin: c.XMin, YMin: c.YMin, XMax: c.XMax, YMax: c.YMax},
+		})
+	}
+
+	return scene, nil
+}
+
+// LoadSceneCSV reads a Scene from a CSV file. Lines beginning with '#'
+// set scene-level options as "#key=value" (min, max, interval, output,
+// width, height, dpi, mode, colormap, contour_count); every other
+// non-empty line is a charge row of
+// "x,y,charge". This keeps the common case - a flat list of charges -
+// trivial to produce from a spreadsheet while still allowing the same
+// bounds/output controls the JSON loader exposes.
+func LoadSceneCSV(path string) (Scene, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Scene{}, fmt.Errorf("opening scene %q: %w", path, err)
+	}
+	defer f.Close()
+
+	scene := DefaultScene()
+	scene.Charges = nil
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if err := applySceneOption(&scene, strings.TrimPrefix(line, "#")); err != nil {
+				return Scene{}, fmt.Errorf("parsing scene %q: %w", path, err)
+			}
+			continue
+		}
+
+		r := csv.NewReader(strings.NewReader(line))
+		fields, err := r.Read()
+		if err != nil {
+			return Scene{}, fmt.Errorf("parsing scene %q: %w", path, err)
+		}
+		if len(fields) != 3 {
+			continue
+		}
+		x, errX := strconv.ParseFloat(strings.TrimSpace(fields[0]), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		q, errQ := strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+		if errX != nil || errY != nil || errQ != nil {
+			continue // header row such as "x,y,charge"
+		}
+		scene.Charges = append(scene.Charges, Point{X: x, Y: y, Charge: q})
+	}
+	if err := scanner.Err(); err != nil {
+		return Scene{}, fmt.Errorf("reading scene %q: %w", path, err)
+	}
+
+	return scene, nil
+}
+
+func applySceneOption(scene *Scene, opt string) error {
+	parts := strings.SplitN(opt, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid scene option %q", opt)
+	}
+	key := strings.TrimSpace(parts[0])
+	value := strings.TrimSpace(parts[1])
+
+	stringOption := key == "output" || key == "mode" || key == "colormap"
+
+	var v float64
+	var err error
+	if !stringOption {
+		v, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value for %q: %w", key, err)
+		}
+	}
+
+	switch key {
+	case "min":
+		scene.MinValue = v
+	case "max":
+		scene.MaxValue = v
+	case "interval":
+		scene.Interval = v
+	case "output":
+		scene.OutputPath = value
+	case "width":
+		scene.Width = vg.Length(v) * vg.Inch
+	case "height":
+		scene.Height = vg.Length(v) * vg.Inch
+	case "dpi":
+		scene.DPI = v
+	case "mode":
+		scene.Mode = value
+	case "colormap":
+		scene.Colormap = value
+	case "contour_count":
+		scene.ContourCount = int(v)
+	default:
+		return fmt.Errorf("unknown scene option %q", key)
+	}
+	return nil
+}
+
+// Run renders the field plot described by scene and writes it to
+// scene.OutputPath. It is the single entry point used by both the
+// interactive prompt and any batch/headless caller - scripts, tests,
+// or tooling that wants to drive the simulation without stdin.
+func Run(scene Scene) error {
+	MinValue = scene.MinValue
+	MaxValue = scene.MaxValue
+
+	interval := scene.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	testPoints := GenerateTestPoints(interval, scene.Charges)
+
+	cmap, err := ParseColormap(scene.Colormap)
+	if err != nil {
+		return err
+	}
+
+	field := regionAdjustedField(scene, interval, fieldEvaluator(scene.Charges))
+
+	p := plot.New()
+	p.Title.Text = "Electric Field Vectors"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	PlotRegions(p, scene)
+	PlotPoints(p, scene.Charges, testPoints)
+
+	switch scene.Mode {
+	case "", "arrows":
+		PlotElectricFieldLines(p, field, testPoints, cmap)
+	case "streamlines":
+		seeds := GenerateStreamlineSeeds(scene.Charges, 12, interval/2)
+		PlotStreamlines(p, scene.Charges, field, interval, seeds, cmap)
+	default:
+		return fmt.Errorf("unknown render mode %q", scene.Mode)
+	}
+
+	levels := scene.ContourLevels
+	if len(levels) == 0 && scene.ContourCount > 0 {
+		levels = AutoContourLevels(scene.Charges, interval, scene.ContourCount)
+	}
+	if len(levels) > 0 {
+		PlotEquipotentials(p, scene.Charges, interval, levels)
+	}
+
+	p.X.Min = MinValue
+	p.X.Max = MaxValue
+	p.Y.Min = MinValue
+	p.Y.Max = MaxValue
+
+	width, height := scene.Width, scene.Height
+	if width == 0 {
+		width = 8 * vg.Inch
+	}
+	if height == 0 {
+		height = 8 * vg.Inch
+	}
+
+	if err := p.Save(width, height, scene.OutputPath); err != nil {
+		return fmt.Errorf("could not save plot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSceneJSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.json")
+	const data = `{
+		"charges": [{"x": 5, "y": 5, "charge": 1}, {"x": -5, "y": 0, "charge": -2}],
+		"min_value": -10,
+		"max_value": 10,
+		"interval": 2,
+		"output_path": "out.png",
+		"mode": "streamlines",
+		"colormap": "plasma",
+		"conductors": [{"x_min": 0, "y_min": 0, "x_max": 1, "y_max": 1}],
+		"dielectrics": [{"x_min": 2, "y_min": 2, "x_max": 3, "y_max": 3, "epsilon_r": 4}]
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	scene, err := LoadSceneJSON(path)
+	if err != nil {
+		t.Fatalf("LoadSceneJSON() error = %v", err)
+	}
+
+	if len(scene.Charges) != 2 || scene.Charges[0] != (Point{X: 5, Y: 5, Charge: 1}) || scene.Charges[1] != (Point{X: -5, Y: 0, Charge: -2}) {
+		t.Errorf("Charges = %+v, want the two charges from the fixture", scene.Charges)
+	}
+	if scene.MinValue != -10 || scene.MaxValue != 10 {
+		t.Errorf("MinValue/MaxValue = %v/%v, want -10/10", scene.MinValue, scene.MaxValue)
+	}
+	if scene.Interval != 2 {
+		t.Errorf("Interval = %v, want 2", scene.Interval)
+	}
+	if scene.OutputPath != "out.png" {
+		t.Errorf("OutputPath = %q, want %q", scene.OutputPath, "out.png")
+	}
+	if scene.Mode != "streamlines" || scene.Colormap != "plasma" {
+		t.Errorf("Mode/Colormap = %q/%q, want streamlines/plasma", scene.Mode, scene.Colormap)
+	}
+	if len(scene.Conductors) != 1 || scene.Conductors[0].Rect.XMax != 1 {
+		t.Errorf("Conductors = %+v, want one conductor with XMax=1", scene.Conductors)
+	}
+	if len(scene.Dielectrics) != 1 || scene.Dielectrics[0].EpsilonR != 4 {
+		t.Errorf("Dielectrics = %+v, want one dielectric with EpsilonR=4", scene.Dielectrics)
+	}
+}
+
+func TestLoadSceneJSONDefaultsWhenFieldsOmitted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.json")
+	const data = `{"charges": [{"x": 1, "y": 1, "charge": 1}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	scene, err := LoadSceneJSON(path)
+	if err != nil {
+		t.Fatalf("LoadSceneJSON() error = %v", err)
+	}
+
+	want := DefaultScene()
+	if scene.MaxValue != want.MaxValue {
+		t.Errorf("MaxValue = %v, want default %v when omitted", scene.MaxValue, want.MaxValue)
+	}
+	if scene.Interval != want.Interval {
+		t.Errorf("Interval = %v, want default %v when omitted", scene.Interval, want.Interval)
+	}
+	if scene.OutputPath != want.OutputPath {
+		t.Errorf("OutputPath = %q, want default %q when omitted", scene.OutputPath, want.OutputPath)
+	}
+	if scene.Mode != want.Mode || scene.Colormap != want.Colormap {
+		t.Errorf("Mode/Colormap = %q/%q, want defaults %q/%q", scene.Mode, scene.Colormap, want.Mode, want.Colormap)
+	}
+	// min_value is not defaulted when omitted: an explicit 0 and an
+	// absent field are indistinguishable in the JSON loader's current
+	// design, so a scene that means "start at 0" round-trips correctly.
+	if scene.MinValue != 0 {
+		t.Errorf("MinValue = %v, want 0 when omitted", scene.MinValue)
+	}
+}
+
+func TestLoadSceneCSVRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.csv")
+	const data = "#min=-5\n#max=15\n#interval=0.5\n#output=csv-out.png\n#mode=streamlines\nx,y,charge\n1,2,3\n-1,-2,-3\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	scene, err := LoadSceneCSV(path)
+	if err != nil {
+		t.Fatalf("LoadSceneCSV() error = %v", err)
+	}
+
+	if len(scene.Charges) != 2 || scene.Charges[0] != (Point{X: 1, Y: 2, Charge: 3}) || scene.Charges[1] != (Point{X: -1, Y: -2, Charge: -3}) {
+		t.Errorf("Charges = %+v, want the two rows from the fixture (header row skipped)", scene.Charges)
+	}
+	if scene.MinValue != -5 || scene.MaxValue != 15 {
+		t.Errorf("MinValue/MaxValue = %v/%v, want -5/15", scene.MinValue, scene.MaxValue)
+	}
+	if scene.Interval != 0.5 {
+		t.Errorf("Interval = %v, want 0.5", scene.Interval)
+	}
+	if scene.OutputPath != "csv-out.png" {
+		t.Errorf("OutputPath = %q, want %q", scene.OutputPath, "csv-out.png")
+	}
+	if scene.Mode != "streamlines" {
+		t.Errorf("Mode = %q, want streamlines", scene.Mode)
+	}
+}
+
+func TestLoadSceneCSVDefaultsWhenNoOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.csv")
+	if err := os.WriteFile(path, []byte("1,1,1\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	scene, err := LoadSceneCSV(path)
+	if err != nil {
+		t.Fatalf("LoadSceneCSV() error = %v", err)
+	}
+
+	want := DefaultScene()
+	if scene.MinValue != want.MinValue || scene.MaxValue != want.MaxValue {
+		t.Errorf("MinValue/MaxValue = %v/%v, want defaults %v/%v", scene.MinValue, scene.MaxValue, want.MinValue, want.MaxValue)
+	}
+	if scene.Interval != want.Interval {
+		t.Errorf("Interval = %v, want default %v", scene.Interval, want.Interval)
+	}
+}
+
+func TestRunWritesOutputFile(t *testing.T) {
+	scene := DefaultScene()
+	scene.Charges = []Point{{X: 10, Y: 10, Charge: 1}}
+	scene.Interval = 5
+	scene.OutputPath = filepath.Join(t.TempDir(), "field.png")
+
+	if err := Run(scene); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	info, err := os.Stat(scene.OutputPath)
+	if err != nil {
+		t.Fatalf("Run() did not produce %q: %v", scene.OutputPath, err)
+	}
+	if info.Size() == 0 {
+		t.Errorf("Run() produced an empty file at %q", scene.OutputPath)
+	}
+}